@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// CorpusDir is where per-user activity snapshots are persisted between runs.
+const CorpusDir = ".corpus"
+
+// CommitRecord is a single commit pulled from a user's events/repos.
+type CommitRecord struct {
+	Repo      string    `json:"repo"`
+	SHA       string    `json:"sha"`
+	Message   string    `json:"message"`
+	Additions int       `json:"additions"`
+	Deletions int       `json:"deletions"`
+	Date      time.Time `json:"date"`
+}
+
+// IssueRecord tracks an issue the user opened or closed.
+type IssueRecord struct {
+	Repo     string    `json:"repo"`
+	Number   int       `json:"number"`
+	Title    string    `json:"title"`
+	State    string    `json:"state"`
+	OpenedAt time.Time `json:"opened_at"`
+	ClosedAt time.Time `json:"closed_at,omitempty"`
+}
+
+// PullRequestRecord tracks a PR the user authored or reviewed.
+type PullRequestRecord struct {
+	Repo        string    `json:"repo"`
+	Number      int       `json:"number"`
+	Author      bool      `json:"author"`
+	Reviewer    bool      `json:"reviewer"`
+	OpenedAt    time.Time `json:"opened_at"`
+	MergedAt    time.Time `json:"merged_at,omitempty"`
+	FirstReview time.Time `json:"first_review,omitempty"`
+}
+
+// CommentRecord tracks a single comment left by the user, for cadence analysis.
+type CommentRecord struct {
+	Repo      string    `json:"repo"`
+	Target    string    `json:"target"` // "issue" or "pull_request"
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CollaboratorRecord tracks another contributor seen alongside the user on a
+// shared repo, backing the collab-graph mode's co-contributor mapping.
+type CollaboratorRecord struct {
+	Repo     string `json:"repo"`
+	Username string `json:"username"`
+}
+
+// Corpus is the persisted, incrementally-updated record of a user's GitHub
+// activity. It backs every analytics mode so they can run offline once a
+// `fetch` has populated it.
+type Corpus struct {
+	Username      string               `json:"username"`
+	LastFetchedAt time.Time            `json:"last_fetched_at"`
+	Commits       []CommitRecord       `json:"commits"`
+	Issues        []IssueRecord        `json:"issues"`
+	PullRequests  []PullRequestRecord  `json:"pull_requests"`
+	Comments      []CommentRecord      `json:"comments"`
+	Collaborators []CollaboratorRecord `json:"collaborators"`
+	Languages     map[string]int       `json:"languages"` // language -> bytes/occurrences seen
+}
+
+func newCorpus(username string) *Corpus {
+	return &Corpus{
+		Username:  username,
+		Languages: make(map[string]int),
+	}
+}
+
+// validGitHubUsername matches GitHub's allowed login charset (alphanumerics
+// and hyphens). It's deliberately stricter than GitHub's own rules (which
+// also forbid leading/trailing/doubled hyphens) since all we need here is to
+// keep a username from ever being interpreted as a path component like ".."
+// or "/".
+var validGitHubUsername = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+
+// validateUsername rejects anything that isn't a plausible GitHub login,
+// since every caller feeds it straight into corpusPath and then a real
+// filesystem path.
+func validateUsername(username string) error {
+	if !validGitHubUsername.MatchString(username) {
+		return fmt.Errorf("invalid username %q: must match %s", username, validGitHubUsername)
+	}
+	return nil
+}
+
+func corpusPath(username string) string {
+	return filepath.Join(CorpusDir, username+".json")
+}
+
+// loadCorpus reads the persisted corpus for username, returning a fresh,
+// empty corpus if none has been fetched yet.
+func loadCorpus(username string) (*Corpus, error) {
+	if err := validateUsername(username); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(corpusPath(username))
+	if os.IsNotExist(err) {
+		return newCorpus(username), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading corpus for %s: %w", username, err)
+	}
+
+	corpus := newCorpus(username)
+	if err := json.Unmarshal(data, corpus); err != nil {
+		return nil, fmt.Errorf("parsing corpus for %s: %w", username, err)
+	}
+	return corpus, nil
+}
+
+// saveCorpus persists the corpus to disk, creating CorpusDir if needed.
+func saveCorpus(corpus *Corpus) error {
+	if err := validateUsername(corpus.Username); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(CorpusDir, 0o755); err != nil {
+		return fmt.Errorf("creating corpus dir: %w", err)
+	}
+
+	data, err := json.MarshalIndent(corpus, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling corpus for %s: %w", corpus.Username, err)
+	}
+
+	if err := os.WriteFile(corpusPath(corpus.Username), data, 0o644); err != nil {
+		return fmt.Errorf("writing corpus for %s: %w", corpus.Username, err)
+	}
+	return nil
+}
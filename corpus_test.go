@@ -0,0 +1,51 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+func TestValidateUsername(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		wantErr bool
+	}{
+		{"simple username", "octocat", false},
+		{"hyphenated username", "the-octocat", false},
+		{"path traversal", "../../../etc/passwd", true},
+		{"embedded slash", "foo/bar", true},
+		{"empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateUsername(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateUsername(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadCorpusRejectsPathTraversal(t *testing.T) {
+	withTempCacheDir(t)
+
+	// A file a path-traversing username could otherwise reach.
+	if err := os.WriteFile("secret.json", []byte(`{"leaked":true}`), 0o644); err != nil {
+		t.Fatalf("writing fixture file: %v", err)
+	}
+
+	if _, err := loadCorpus("../secret"); err == nil {
+		t.Fatal("expected loadCorpus to reject a path-traversing username")
+	}
+}
+
+func TestSaveCorpusRejectsPathTraversal(t *testing.T) {
+	withTempCacheDir(t)
+
+	corpus := newCorpus("../escape")
+	if err := saveCorpus(corpus); err == nil {
+		t.Fatal("expected saveCorpus to reject a path-traversing username")
+	}
+}
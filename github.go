@@ -0,0 +1,504 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// linkNextPattern pulls the `next` URL out of a GitHub `Link` response
+// header, e.g. `<https://api.github.com/...&page=2>; rel="next"`.
+var linkNextPattern = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+func nextPageURL(header http.Header) string {
+	match := linkNextPattern.FindStringSubmatch(header.Get("Link"))
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+// fetchIncremental pulls everything new since corpus.LastFetchedAt into the
+// corpus: the repo list (paginated well beyond the old 15-repo cap, and always
+// refetched in full since it's cheap), commits, issues, pull requests and
+// comments authored/reviewed by the user, and co-contributors per repo.
+// Commits/issues/comments are scoped server-side with `since`, and every
+// record is additionally deduped against what's already in the corpus (by
+// SHA, repo#number, or repo+target+timestamp) so re-running `fetch` never
+// produces duplicate entries or double-counts Languages.
+func fetchIncremental(corpus *Corpus, token string) error {
+	client := getGitHubClient(token)
+	since := corpus.LastFetchedAt
+
+	repos, err := fetchAllRepos(client, corpus.Username, token)
+	if err != nil {
+		return err
+	}
+
+	seenCommits := make(map[string]bool, len(corpus.Commits))
+	for _, c := range corpus.Commits {
+		seenCommits[c.SHA] = true
+	}
+	seenIssues := make(map[string]bool, len(corpus.Issues))
+	for _, i := range corpus.Issues {
+		seenIssues[repoNumberKey(i.Repo, i.Number)] = true
+	}
+	seenPRs := make(map[string]bool, len(corpus.PullRequests))
+	for _, pr := range corpus.PullRequests {
+		seenPRs[repoNumberKey(pr.Repo, pr.Number)] = true
+	}
+	seenComments := make(map[string]bool, len(corpus.Comments))
+	for _, cm := range corpus.Comments {
+		seenComments[commentKey(cm)] = true
+	}
+	seenCollaborators := make(map[string]bool, len(corpus.Collaborators))
+	for _, c := range corpus.Collaborators {
+		seenCollaborators[repoUserKey(c.Repo, c.Username)] = true
+	}
+
+	// Repos are always refetched in full, so Languages is rebuilt from
+	// scratch each run rather than incremented, to avoid double-counting.
+	corpus.Languages = make(map[string]int)
+
+	for _, repo := range repos {
+		if repo.Language != "" {
+			corpus.Languages[repo.Language]++
+		}
+
+		commits, err := fetchRepoCommits(client, token, corpus.Username, repo.Name, since)
+		if err != nil {
+			return fmt.Errorf("fetching commits for %s: %w", repo.Name, err)
+		}
+		for _, c := range commits {
+			if seenCommits[c.SHA] {
+				continue
+			}
+			seenCommits[c.SHA] = true
+			corpus.Commits = append(corpus.Commits, c)
+		}
+
+		issues, err := fetchRepoIssues(client, token, corpus.Username, repo.Name, since)
+		if err != nil {
+			return fmt.Errorf("fetching issues for %s: %w", repo.Name, err)
+		}
+		for _, i := range issues {
+			key := repoNumberKey(i.Repo, i.Number)
+			if seenIssues[key] {
+				continue
+			}
+			seenIssues[key] = true
+			corpus.Issues = append(corpus.Issues, i)
+		}
+
+		prs, err := fetchRepoPullRequests(client, token, corpus.Username, repo.Name)
+		if err != nil {
+			return fmt.Errorf("fetching pull requests for %s: %w", repo.Name, err)
+		}
+		for _, pr := range prs {
+			key := repoNumberKey(pr.Repo, pr.Number)
+			if seenPRs[key] {
+				continue
+			}
+			seenPRs[key] = true
+			corpus.PullRequests = append(corpus.PullRequests, pr)
+		}
+
+		comments, err := fetchRepoComments(client, token, corpus.Username, repo.Name, since)
+		if err != nil {
+			return fmt.Errorf("fetching comments for %s: %w", repo.Name, err)
+		}
+		for _, cm := range comments {
+			key := commentKey(cm)
+			if seenComments[key] {
+				continue
+			}
+			seenComments[key] = true
+			corpus.Comments = append(corpus.Comments, cm)
+		}
+
+		collaborators, err := fetchRepoCollaborators(client, token, corpus.Username, repo.Name)
+		if err != nil {
+			return fmt.Errorf("fetching collaborators for %s: %w", repo.Name, err)
+		}
+		for _, name := range collaborators {
+			key := repoUserKey(repo.Name, name)
+			if seenCollaborators[key] {
+				continue
+			}
+			seenCollaborators[key] = true
+			corpus.Collaborators = append(corpus.Collaborators, CollaboratorRecord{Repo: repo.Name, Username: name})
+		}
+	}
+
+	corpus.LastFetchedAt = time.Now()
+	return nil
+}
+
+func repoNumberKey(repo string, number int) string {
+	return fmt.Sprintf("%s#%d", repo, number)
+}
+
+func repoUserKey(repo, username string) string {
+	return repo + "#" + username
+}
+
+func commentKey(c CommentRecord) string {
+	return fmt.Sprintf("%s|%s|%s", c.Repo, c.Target, c.CreatedAt.Format(time.RFC3339Nano))
+}
+
+// fetchAllRepos walks every page of a user's repos via the `Link: rel="next"`
+// header, rather than stopping at the first 15. Conditional requests and
+// rate-limit backoff are handled by the client's transport (see
+// httpcache.go), so this just follows pages until GitHub stops returning one.
+func fetchAllRepos(client *http.Client, username, token string) ([]Repository, error) {
+	var all []Repository
+	url := fmt.Sprintf("%s/users/%s/repos?sort=updated&per_page=100", githubAPIBase, username)
+
+	for url != "" {
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("creating repo request: %w", err)
+		}
+		req.Header.Add("Authorization", "Bearer "+token)
+		req.Header.Add("Accept", "application/vnd.github.v3+json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("fetching repos: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotModified {
+			resp.Body.Close()
+			return nil, fmt.Errorf("GitHub API error fetching repos: %s", resp.Status)
+		}
+
+		var pageRepos []Repository
+		if resp.StatusCode == http.StatusOK {
+			if err := json.NewDecoder(resp.Body).Decode(&pageRepos); err != nil {
+				resp.Body.Close()
+				return nil, fmt.Errorf("decoding repos: %w", err)
+			}
+		}
+
+		next := nextPageURL(resp.Header)
+		resp.Body.Close()
+
+		all = append(all, pageRepos...)
+		url = next
+	}
+
+	return all, nil
+}
+
+// fetchRepoCommits fetches the commits authored by username in a single repo,
+// scoped to commits made since the last fetch when since is non-zero.
+func fetchRepoCommits(client *http.Client, token, username, repo string, since time.Time) ([]CommitRecord, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/commits?author=%s&per_page=100", githubAPIBase, username, repo, username)
+	if !since.IsZero() {
+		url += "&since=" + since.UTC().Format(time.RFC3339)
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating commits request: %w", err)
+	}
+	req.Header.Add("Authorization", "Bearer "+token)
+	req.Header.Add("Accept", "application/vnd.github.v3+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// A repo with no commits or disabled history shouldn't fail the whole fetch.
+		return nil, nil
+	}
+
+	var raw []struct {
+		SHA    string `json:"sha"`
+		Commit struct {
+			Message string `json:"message"`
+			Author  struct {
+				Date time.Time `json:"date"`
+			} `json:"author"`
+		} `json:"commit"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("decoding commits: %w", err)
+	}
+
+	records := make([]CommitRecord, 0, len(raw))
+	for _, c := range raw {
+		records = append(records, CommitRecord{
+			Repo:    repo,
+			SHA:     c.SHA,
+			Message: c.Commit.Message,
+			Date:    c.Commit.Author.Date,
+		})
+	}
+	return records, nil
+}
+
+// fetchRepoIssues fetches issues opened by username in a single repo,
+// scoped to issues updated since the last fetch when since is non-zero.
+func fetchRepoIssues(client *http.Client, token, username, repo string, since time.Time) ([]IssueRecord, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/issues?creator=%s&state=all&per_page=100", githubAPIBase, username, repo, username)
+	if !since.IsZero() {
+		url += "&since=" + since.UTC().Format(time.RFC3339)
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating issues request: %w", err)
+	}
+	req.Header.Add("Authorization", "Bearer "+token)
+	req.Header.Add("Accept", "application/vnd.github.v3+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil
+	}
+
+	var raw []struct {
+		Number      int        `json:"number"`
+		Title       string     `json:"title"`
+		State       string     `json:"state"`
+		CreatedAt   time.Time  `json:"created_at"`
+		ClosedAt    *time.Time `json:"closed_at"`
+		PullRequest *struct{}  `json:"pull_request"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("decoding issues: %w", err)
+	}
+
+	records := make([]IssueRecord, 0, len(raw))
+	for _, i := range raw {
+		if i.PullRequest != nil {
+			continue // GitHub's issues endpoint also returns PRs; skip those here
+		}
+		record := IssueRecord{
+			Repo:     repo,
+			Number:   i.Number,
+			Title:    i.Title,
+			State:    i.State,
+			OpenedAt: i.CreatedAt,
+		}
+		if i.ClosedAt != nil {
+			record.ClosedAt = *i.ClosedAt
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// fetchRepoPullRequests fetches the pull requests in a single repo that
+// username either authored or reviewed, along with merge/first-review
+// timestamps for the cl-stats mode.
+func fetchRepoPullRequests(client *http.Client, token, username, repo string) ([]PullRequestRecord, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/pulls?state=all&per_page=100", githubAPIBase, username, repo)
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating pull requests request: %w", err)
+	}
+	req.Header.Add("Authorization", "Bearer "+token)
+	req.Header.Add("Accept", "application/vnd.github.v3+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil
+	}
+
+	var raw []struct {
+		Number int `json:"number"`
+		User   struct {
+			Login string `json:"login"`
+		} `json:"user"`
+		CreatedAt time.Time  `json:"created_at"`
+		MergedAt  *time.Time `json:"merged_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("decoding pull requests: %w", err)
+	}
+
+	records := make([]PullRequestRecord, 0, len(raw))
+	for _, pr := range raw {
+		isAuthor := pr.User.Login == username
+
+		reviewed, firstReview, err := fetchFirstReviewBy(client, token, username, repo, pr.Number)
+		if err != nil {
+			return nil, fmt.Errorf("fetching reviews for %s#%d: %w", repo, pr.Number, err)
+		}
+		if !isAuthor && !reviewed {
+			continue
+		}
+
+		record := PullRequestRecord{
+			Repo:     repo,
+			Number:   pr.Number,
+			Author:   isAuthor,
+			Reviewer: reviewed,
+			OpenedAt: pr.CreatedAt,
+		}
+		if pr.MergedAt != nil {
+			record.MergedAt = *pr.MergedAt
+		}
+		if reviewed {
+			record.FirstReview = firstReview
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// fetchFirstReviewBy reports whether reviewer left a review on the given PR
+// and, if so, the timestamp of their earliest one.
+func fetchFirstReviewBy(client *http.Client, token, owner, repo string, number int) (bool, time.Time, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/reviews?per_page=100", githubAPIBase, owner, repo, number)
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("creating reviews request: %w", err)
+	}
+	req.Header.Add("Authorization", "Bearer "+token)
+	req.Header.Add("Accept", "application/vnd.github.v3+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, time.Time{}, nil
+	}
+
+	var raw []struct {
+		User struct {
+			Login string `json:"login"`
+		} `json:"user"`
+		SubmittedAt time.Time `json:"submitted_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return false, time.Time{}, fmt.Errorf("decoding reviews: %w", err)
+	}
+
+	var first time.Time
+	found := false
+	for _, r := range raw {
+		if r.User.Login != owner {
+			continue
+		}
+		if !found || r.SubmittedAt.Before(first) {
+			first = r.SubmittedAt
+			found = true
+		}
+	}
+	return found, first, nil
+}
+
+// fetchRepoComments fetches the issue/PR comments username left in a single
+// repo, scoped to comments made since the last fetch when since is non-zero.
+// GitHub's issue-comments endpoint covers both issues and pull requests, with
+// the target distinguished by whether the comment's URL points at a PR.
+func fetchRepoComments(client *http.Client, token, username, repo string, since time.Time) ([]CommentRecord, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/issues/comments?per_page=100", githubAPIBase, username, repo)
+	if !since.IsZero() {
+		url += "&since=" + since.UTC().Format(time.RFC3339)
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating comments request: %w", err)
+	}
+	req.Header.Add("Authorization", "Bearer "+token)
+	req.Header.Add("Accept", "application/vnd.github.v3+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil
+	}
+
+	var raw []struct {
+		User struct {
+			Login string `json:"login"`
+		} `json:"user"`
+		CreatedAt time.Time `json:"created_at"`
+		HTMLURL   string    `json:"html_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("decoding comments: %w", err)
+	}
+
+	records := make([]CommentRecord, 0, len(raw))
+	for _, c := range raw {
+		if c.User.Login != username {
+			continue
+		}
+		target := "issue"
+		if strings.Contains(c.HTMLURL, "/pull/") {
+			target = "pull_request"
+		}
+		records = append(records, CommentRecord{Repo: repo, Target: target, CreatedAt: c.CreatedAt})
+	}
+	return records, nil
+}
+
+// fetchRepoCollaborators fetches the other contributors on a single repo, for
+// the collab-graph mode's co-contributor mapping.
+func fetchRepoCollaborators(client *http.Client, token, username, repo string) ([]string, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/contributors?per_page=100", githubAPIBase, username, repo)
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating contributors request: %w", err)
+	}
+	req.Header.Add("Authorization", "Bearer "+token)
+	req.Header.Add("Accept", "application/vnd.github.v3+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil
+	}
+
+	var raw []struct {
+		Login string `json:"login"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("decoding contributors: %w", err)
+	}
+
+	var collaborators []string
+	for _, c := range raw {
+		if c.Login == "" || c.Login == username {
+			continue
+		}
+		collaborators = append(collaborators, c.Login)
+	}
+	return collaborators, nil
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// fixtureGitHubServer serves a single repo ("octo/demo") with one commit,
+// one issue, one pull request (with one review), one comment and one other
+// contributor — enough to exercise every seen-map in fetchIncremental.
+func fixtureGitHubServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users/octo/repos", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"name":"demo","language":"Go"}]`))
+	})
+	mux.HandleFunc("/repos/octo/demo/commits", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"sha":"abc123","commit":{"message":"fix bug","author":{"date":"2024-01-01T00:00:00Z"}}}]`))
+	})
+	mux.HandleFunc("/repos/octo/demo/issues/comments", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"user":{"login":"octo"},"created_at":"2024-01-02T00:00:00Z","html_url":"https://github.com/octo/demo/issues/9"}]`))
+	})
+	mux.HandleFunc("/repos/octo/demo/issues", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"number":9,"title":"bug report","state":"open","created_at":"2024-01-01T00:00:00Z"}]`))
+	})
+	mux.HandleFunc("/repos/octo/demo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"number":4,"user":{"login":"octo"},"created_at":"2024-01-01T00:00:00Z","merged_at":"2024-01-03T00:00:00Z"}]`))
+	})
+	mux.HandleFunc("/repos/octo/demo/pulls/4/reviews", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"user":{"login":"octo"},"submitted_at":"2024-01-02T00:00:00Z"}]`))
+	})
+	mux.HandleFunc("/repos/octo/demo/contributors", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"login":"octo"},{"login":"friend"}]`))
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestFetchIncrementalDedupesOnRepeatRuns(t *testing.T) {
+	withTempCacheDir(t)
+
+	server := fixtureGitHubServer()
+	defer server.Close()
+
+	prevBase := githubAPIBase
+	githubAPIBase = server.URL
+	defer func() { githubAPIBase = prevBase }()
+
+	corpus := newCorpus("octo")
+
+	if err := fetchIncremental(corpus, "test-token"); err != nil {
+		t.Fatalf("first fetchIncremental: %v", err)
+	}
+
+	wantCommits, wantIssues, wantPRs, wantComments, wantCollaborators := len(corpus.Commits), len(corpus.Issues), len(corpus.PullRequests), len(corpus.Comments), len(corpus.Collaborators)
+	if wantCommits != 1 || wantIssues != 1 || wantPRs != 1 || wantComments != 1 || wantCollaborators != 1 {
+		t.Fatalf("after first fetch: commits=%d issues=%d prs=%d comments=%d collaborators=%d, want 1 each",
+			wantCommits, wantIssues, wantPRs, wantComments, wantCollaborators)
+	}
+	if corpus.Languages["Go"] != 1 {
+		t.Fatalf("Languages[Go] = %d, want 1", corpus.Languages["Go"])
+	}
+
+	if err := fetchIncremental(corpus, "test-token"); err != nil {
+		t.Fatalf("second fetchIncremental: %v", err)
+	}
+
+	if got := len(corpus.Commits); got != wantCommits {
+		t.Errorf("Commits after repeat fetch = %d, want %d (duplicated)", got, wantCommits)
+	}
+	if got := len(corpus.Issues); got != wantIssues {
+		t.Errorf("Issues after repeat fetch = %d, want %d (duplicated)", got, wantIssues)
+	}
+	if got := len(corpus.PullRequests); got != wantPRs {
+		t.Errorf("PullRequests after repeat fetch = %d, want %d (duplicated)", got, wantPRs)
+	}
+	if got := len(corpus.Comments); got != wantComments {
+		t.Errorf("Comments after repeat fetch = %d, want %d (duplicated)", got, wantComments)
+	}
+	if got := len(corpus.Collaborators); got != wantCollaborators {
+		t.Errorf("Collaborators after repeat fetch = %d, want %d (duplicated)", got, wantCollaborators)
+	}
+	if got := corpus.Languages["Go"]; got != 1 {
+		t.Errorf("Languages[Go] after repeat fetch = %d, want 1 (double-counted)", got)
+	}
+}
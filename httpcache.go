@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// HTTPCacheDir holds the on-disk ETag/Last-Modified cache for GitHub API
+// responses, httpcache-style, so repeat runs don't re-pay for unchanged data.
+const HTTPCacheDir = ".httpcache"
+
+// cacheEntry is everything needed to replay a cached 200 response, or to
+// make a conditional request for it.
+type cacheEntry struct {
+	StatusCode   int
+	Header       http.Header
+	Body         []byte
+	ETag         string
+	LastModified string
+}
+
+func cacheKey(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.Method + " " + req.URL.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+func loadCacheEntry(req *http.Request) (*cacheEntry, bool) {
+	path := filepath.Join(HTTPCacheDir, cacheKey(req)+".gob")
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, false
+	}
+	defer f.Close()
+
+	var entry cacheEntry
+	if err := gob.NewDecoder(f).Decode(&entry); err != nil {
+		return nil, false
+	}
+	return &entry, true
+}
+
+func storeCacheEntry(req *http.Request, entry *cacheEntry) error {
+	if err := os.MkdirAll(HTTPCacheDir, 0o755); err != nil {
+		return err
+	}
+
+	path := filepath.Join(HTTPCacheDir, cacheKey(req)+".gob")
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return gob.NewEncoder(f).Encode(entry)
+}
+
+// rateLimitState tracks GitHub's X-RateLimit-Remaining/X-RateLimit-Reset
+// headers across requests made through the same transport, so the client
+// can sleep out a rate-limit window instead of hammering the API with 403s.
+type rateLimitState struct {
+	mu        sync.Mutex
+	remaining int
+	resetAt   time.Time
+	known     bool
+}
+
+func (s *rateLimitState) update(header http.Header) {
+	remaining, err1 := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	resetUnix, err2 := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if err1 != nil || err2 != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remaining = remaining
+	s.resetAt = time.Unix(resetUnix, 0)
+	s.known = true
+}
+
+// maxRateLimitWait caps how long we'll block for a rate-limit reset; beyond
+// that we'd rather surface a slow/failed request than hang indefinitely.
+const maxRateLimitWait = 60 * time.Second
+
+// waitIfExhausted blocks until the rate-limit window resets (capped at
+// maxRateLimitWait), if the last response we saw reported zero requests
+// remaining.
+func (s *rateLimitState) waitIfExhausted() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.known || s.remaining > 0 {
+		return
+	}
+	wait := time.Until(s.resetAt)
+	if wait > maxRateLimitWait {
+		wait = maxRateLimitWait
+	}
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+// githubTransport wraps the default transport with a disk cache, GitHub
+// rate-limit awareness, and exponential backoff on 5xx responses.
+type githubTransport struct {
+	base http.RoundTripper
+	rate *rateLimitState
+}
+
+const maxRetries = 3
+
+func (t *githubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.rate.waitIfExhausted()
+
+	cached, hasCache := loadCacheEntry(req)
+	if hasCache {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	var resp *http.Response
+	var err error
+	backoff := 500 * time.Millisecond
+
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		resp, err = t.base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode < 500 {
+			break
+		}
+		resp.Body.Close()
+		if attempt == maxRetries {
+			break
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+
+	t.rate.update(resp.Header)
+
+	if resp.StatusCode == http.StatusNotModified && hasCache {
+		resp.Body.Close()
+		return &http.Response{
+			StatusCode: cached.StatusCode,
+			Header:     cached.Header,
+			Body:       io.NopCloser(bytes.NewReader(cached.Body)),
+			Request:    req,
+		}, nil
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		body, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr != nil {
+			return nil, readErr
+		}
+
+		entry := &cacheEntry{
+			StatusCode:   resp.StatusCode,
+			Header:       resp.Header,
+			Body:         body,
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+		}
+		if err := storeCacheEntry(req, entry); err != nil {
+			return nil, fmt.Errorf("writing http cache entry: %w", err)
+		}
+
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	return resp, nil
+}
+
+// githubClients holds one rate-limit tracker per token, since RoundTrippers
+// are typically short-lived (one per getGitHubClient call) but the rate
+// limit window is per-credential.
+var (
+	githubRateStates   = make(map[string]*rateLimitState)
+	githubRateStatesMu sync.Mutex
+)
+
+func rateStateFor(token string) *rateLimitState {
+	githubRateStatesMu.Lock()
+	defer githubRateStatesMu.Unlock()
+
+	if state, ok := githubRateStates[token]; ok {
+		return state
+	}
+	state := &rateLimitState{}
+	githubRateStates[token] = state
+	return state
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNextPageURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{
+			name:   "has next link",
+			header: `<https://api.github.com/resource?page=2>; rel="next", <https://api.github.com/resource?page=5>; rel="last"`,
+			want:   "https://api.github.com/resource?page=2",
+		},
+		{
+			name:   "no next link",
+			header: `<https://api.github.com/resource?page=5>; rel="last"`,
+			want:   "",
+		},
+		{
+			name:   "empty header",
+			header: "",
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.header != "" {
+				header.Set("Link", tt.header)
+			}
+			if got := nextPageURL(header); got != tt.want {
+				t.Errorf("nextPageURL(%q) = %q, want %q", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeRoundTripper replays a fixed sequence of responses, one per call, and
+// records every request it saw.
+type fakeRoundTripper struct {
+	responses []*http.Response
+	calls     int
+	requests  []*http.Request
+}
+
+func (f *fakeRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.requests = append(f.requests, req)
+	resp := f.responses[f.calls]
+	f.calls++
+	return resp, nil
+}
+
+func newFakeResponse(status int, body string, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+	}
+}
+
+// withTempCacheDir runs a test with the working directory set to a fresh
+// temp dir, so githubTransport's on-disk cache doesn't touch the repo.
+func withTempCacheDir(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	prev, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Chdir: %v", err)
+	}
+	t.Cleanup(func() { os.Chdir(prev) })
+}
+
+func TestGithubTransportRetriesOn5xx(t *testing.T) {
+	withTempCacheDir(t)
+
+	base := &fakeRoundTripper{responses: []*http.Response{
+		newFakeResponse(http.StatusInternalServerError, "", nil),
+		newFakeResponse(http.StatusInternalServerError, "", nil),
+		newFakeResponse(http.StatusOK, `{"ok":true}`, nil),
+	}}
+	transport := &githubTransport{base: base, rate: &rateLimitState{}}
+
+	req := httptest.NewRequest(http.MethodGet, "https://api.github.com/repos/owner/repo", nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if base.calls != 3 {
+		t.Errorf("base transport called %d times, want 3", base.calls)
+	}
+}
+
+func TestGithubTransportServesFromCacheOn304(t *testing.T) {
+	withTempCacheDir(t)
+
+	firstHeader := http.Header{}
+	firstHeader.Set("ETag", `"abc123"`)
+	base := &fakeRoundTripper{responses: []*http.Response{
+		newFakeResponse(http.StatusOK, `{"name":"repo"}`, firstHeader),
+		newFakeResponse(http.StatusNotModified, "", nil),
+	}}
+	transport := &githubTransport{base: base, rate: &rateLimitState{}}
+
+	req1 := httptest.NewRequest(http.MethodGet, "https://api.github.com/repos/owner/repo", nil)
+	resp1, err := transport.RoundTrip(req1)
+	if err != nil {
+		t.Fatalf("first RoundTrip: %v", err)
+	}
+	body1, _ := io.ReadAll(resp1.Body)
+	resp1.Body.Close()
+
+	req2 := httptest.NewRequest(http.MethodGet, "https://api.github.com/repos/owner/repo", nil)
+	resp2, err := transport.RoundTrip(req2)
+	if err != nil {
+		t.Fatalf("second RoundTrip: %v", err)
+	}
+	body2, _ := io.ReadAll(resp2.Body)
+	resp2.Body.Close()
+
+	if string(body1) != string(body2) {
+		t.Errorf("cached body = %q, want %q", body2, body1)
+	}
+	if got := req2.Header.Get("If-None-Match"); got != `"abc123"` {
+		t.Errorf("If-None-Match = %q, want %q", got, `"abc123"`)
+	}
+	if base.calls != 2 {
+		t.Errorf("base transport called %d times, want 2", base.calls)
+	}
+
+	if _, err := os.Stat(filepath.Join(HTTPCacheDir, cacheKey(req1)+".gob")); err != nil {
+		t.Errorf("expected a cache entry on disk: %v", err)
+	}
+}
@@ -43,118 +43,131 @@ type Repository struct {
 
 // ProfileAnalysis represents the AI-generated analysis
 type ProfileAnalysis struct {
-	PersonalityType string
-	Strengths       []string
-	Areas           []string
-	Suggestions     []string
-	TechStack       []string
-	ActivityLevel   string
+	PersonalityType  string
+	Strengths        []string
+	Areas            []string
+	Suggestions      []string
+	TechStack        []string
+	ActivityLevel    string
+	SecurityFindings []Finding
 }
 
-func loadEnv() {
-	err := godotenv.Load()
-	if err != nil {
-		log.Fatal("Error loading .env file. Please ensure you have a .env file in the project root.")
+func loadEnv() error {
+	if err := godotenv.Load(); err != nil {
+		return fmt.Errorf("loading .env file: %w (please ensure you have a .env file in the project root)", err)
 	}
+	return nil
 }
 
+// githubAPIBase is the GitHub REST API root. It's a var rather than an
+// inline literal so tests can point it at an httptest server.
+var githubAPIBase = "https://api.github.com"
+
 func getGitHubClient(token string) *http.Client {
-	client := &http.Client{
-		Timeout: time.Second * 10,
+	return &http.Client{
+		Timeout: time.Second * 30,
+		Transport: &githubTransport{
+			base: http.DefaultTransport,
+			rate: rateStateFor(token),
+		},
 	}
-	return client
 }
 
-func fetchGitHubData(username, token string) (*GitHubUser, []Repository) {
+// fetchGitHubData fetches a user's profile and full repo list (paginated
+// beyond the old 15-repo cap via fetchAllRepos). Errors are returned rather
+// than fatal so this is safe to call from a long-running server process.
+func fetchGitHubData(username, token string) (*GitHubUser, []Repository, error) {
 	client := getGitHubClient(token)
-	
-	// Fetch user profile
-	userReq, err := http.NewRequest("GET", fmt.Sprintf("https://api.github.com/users/%s", username), nil)
+
+	userReq, err := http.NewRequest("GET", fmt.Sprintf("%s/users/%s", githubAPIBase, username), nil)
 	if err != nil {
-		log.Fatalf("Error creating request: %v", err)
+		return nil, nil, fmt.Errorf("creating request: %w", err)
 	}
-	
+
 	userReq.Header.Add("Authorization", "Bearer "+token)
 	userReq.Header.Add("Accept", "application/vnd.github.v3+json")
-	
+
 	userResp, err := client.Do(userReq)
 	if err != nil {
-		log.Fatalf("Error fetching user data: %v", err)
+		return nil, nil, fmt.Errorf("fetching user data: %w", err)
 	}
 	defer userResp.Body.Close()
 
 	if userResp.StatusCode != http.StatusOK {
-		log.Fatalf("GitHub API error: %s", userResp.Status)
+		return nil, nil, fmt.Errorf("GitHub API error: %s", userResp.Status)
 	}
 
 	var userData GitHubUser
 	if err := json.NewDecoder(userResp.Body).Decode(&userData); err != nil {
-		log.Fatalf("Error decoding user data: %v", err)
+		return nil, nil, fmt.Errorf("decoding user data: %w", err)
 	}
 
-	// Fetch repositories
-	repoReq, err := http.NewRequest("GET", 
-		fmt.Sprintf("https://api.github.com/users/%s/repos?sort=updated&per_page=15", username), nil)
-	if err != nil {
-		log.Fatalf("Error creating repo request: %v", err)
-	}
-	
-	repoReq.Header.Add("Authorization", "Bearer "+token)
-	repoReq.Header.Add("Accept", "application/vnd.github.v3+json")
-	
-	repoResp, err := client.Do(repoReq)
+	repos, err := fetchAllRepos(client, username, token)
 	if err != nil {
-		log.Fatalf("Error fetching repositories: %v", err)
+		return nil, nil, fmt.Errorf("fetching repositories: %w", err)
 	}
-	defer repoResp.Body.Close()
 
-	var repos []Repository
-	if err := json.NewDecoder(repoResp.Body).Decode(&repos); err != nil {
-		log.Fatalf("Error decoding repository data: %v", err)
-	}
+	return &userData, repos, nil
+}
 
-	return &userData, repos
+func newGeminiClient(ctx context.Context, geminiKey string) (*genai.Client, error) {
+	client, err := genai.NewClient(ctx, option.WithAPIKey(geminiKey))
+	if err != nil {
+		return nil, fmt.Errorf("creating Gemini client: %w", err)
+	}
+	return client, nil
 }
 
-func generateProfileAnalysis(ctx context.Context, client *genai.Client, user *GitHubUser, repos []Repository) *ProfileAnalysis {
-    model := client.GenerativeModel("gemini-pro")
-    
-    // Prepare prompt for Gemini
-    prompt := fmt.Sprintf(`Analyze this GitHub profile and provide a detailed personality assessment:
+// generateProfileAnalysis reasons over one or more DeveloperProfiles (one
+// per forge a user was found on), so Gemini can comment on cross-forge
+// behavior such as being active on a self-hosted Gitea but dormant on
+// github.com.
+// buildAnalysisPrompt is shared by the one-shot and streaming (serve mode)
+// analysis paths so both ask Gemini the same question.
+func buildAnalysisPrompt(profiles []*DeveloperProfile, securityFindings []Finding) string {
+	primary := profiles[0]
+
+	return fmt.Sprintf(`Analyze this developer's profile across every forge it was found on and provide a detailed personality assessment:
 
 Profile Information:
 - Name: %s
 - Bio: %s
 - Company: %s
 - Location: %s
-- Account Stats: %d followers, %d following, %d public repos
 - Member since: %s
 
-Recent Repository Analysis:
 %s
 
+Security scan of the top repos: %s
+
 Please provide:
 1. Developer Personality Type (be creative and specific)
 2. Key Technical Strengths (3-4 bullet points)
 3. Areas for Profile Enhancement (2-3 points)
 4. Specific Recommendations for Improvement
 5. Primary Technology Stack
-6. Activity Level Assessment
+6. Activity Level Assessment, including a brief security hygiene note based on the scan above`,
+		primary.Name, primary.Bio, primary.Company, primary.Location,
+		primary.CreatedAt.Format("January 2006"),
+		formatProfilesForPrompt(profiles),
+		summarizeFindings(securityFindings))
+}
+
+func generateProfileAnalysis(ctx context.Context, client *genai.Client, profiles []*DeveloperProfile, securityFindings []Finding) (*ProfileAnalysis, error) {
+    model := client.GenerativeModel("gemini-pro")
+    model.ResponseMIMEType = "application/json"
+    model.ResponseSchema = profileAnalysisSchema()
 
-Format the response in clear sections with headers.`,
-        user.Name, user.Bio, user.Company, user.Location,
-        user.Followers, user.Following, user.PublicRepos,
-        user.CreatedAt.Format("January 2006"),
-        formatReposForPrompt(repos))
+    prompt := buildAnalysisPrompt(profiles, securityFindings)
 
     response, err := model.GenerateContent(ctx, genai.Text(prompt))
     if err != nil {
-        log.Fatalf("Error generating analysis: %v", err)
+        return nil, fmt.Errorf("generating analysis: %w", err)
     }
 
     // Check if we have a response
     if response == nil || len(response.Candidates) == 0 {
-        log.Fatal("No response received from Gemini")
+        return nil, fmt.Errorf("no response received from Gemini")
     }
 
     // Get the text from the response
@@ -166,11 +179,45 @@ Format the response in clear sections with headers.`,
     }
 
     if responseText == "" {
-        log.Fatal("No text content in Gemini response")
+        return nil, fmt.Errorf("no text content in Gemini response")
     }
 
 	fmt.Println(responseText)
-    return parseGeminiResponse(responseText)
+    analysis := parseProfileAnalysis(responseText)
+    analysis.SecurityFindings = securityFindings
+    return analysis, nil
+}
+
+// profileAnalysisSchema mirrors ProfileAnalysis so Gemini's structured-output
+// mode returns exactly the fields we need, instead of free-form markdown
+// that parseGeminiResponse has to guess at.
+func profileAnalysisSchema() *genai.Schema {
+	stringArray := &genai.Schema{Type: genai.TypeArray, Items: &genai.Schema{Type: genai.TypeString}}
+
+	return &genai.Schema{
+		Type: genai.TypeObject,
+		Properties: map[string]*genai.Schema{
+			"PersonalityType": {Type: genai.TypeString},
+			"Strengths":       stringArray,
+			"Areas":           stringArray,
+			"Suggestions":     stringArray,
+			"TechStack":       stringArray,
+			"ActivityLevel":   {Type: genai.TypeString},
+		},
+		Required: []string{"PersonalityType", "Strengths", "Areas", "Suggestions", "TechStack", "ActivityLevel"},
+	}
+}
+
+// parseProfileAnalysis decodes Gemini's structured JSON output into a
+// ProfileAnalysis, falling back to the legacy markdown-header parser for
+// older models that don't honor ResponseMIMEType/ResponseSchema.
+func parseProfileAnalysis(response string) *ProfileAnalysis {
+	var analysis ProfileAnalysis
+	if err := json.Unmarshal([]byte(response), &analysis); err == nil {
+		return &analysis
+	}
+
+	return parseGeminiResponse(response)
 }
 
 
@@ -178,13 +225,33 @@ func formatReposForPrompt(repos []Repository) string {
 	var repoStrings []string
 	for _, repo := range repos {
 		if !repo.Fork {
-			repoStrings = append(repoStrings, fmt.Sprintf("- %s (%s): %s [%d stars]", 
+			repoStrings = append(repoStrings, fmt.Sprintf("- %s (%s): %s [%d stars]",
 				repo.Name, repo.Language, repo.Description, repo.Stars))
 		}
 	}
 	return strings.Join(repoStrings, "\n")
 }
 
+// formatProfilesForPrompt renders one section per forge the developer was
+// found on, each with its own repo list and activity note, so Gemini sees
+// the full cross-forge picture rather than a single GitHub snapshot.
+func formatProfilesForPrompt(profiles []*DeveloperProfile) string {
+	var sections []string
+	for _, profile := range profiles {
+		host := profile.Host
+		if host == "" {
+			host = profile.Source
+		}
+
+		section := fmt.Sprintf("Source: %s (%s)\n- %s\n- %d public repos, %d open / %d closed issues\n\nRepositories:\n%s",
+			profile.Source, host, sourceDormancyNote(profile),
+			profile.PublicRepos, profile.OpenIssues, profile.ClosedIssues,
+			formatReposForPrompt(profile.Repos))
+		sections = append(sections, section)
+	}
+	return strings.Join(sections, "\n\n")
+}
+
 func parseGeminiResponse(response string) *ProfileAnalysis {
 	analysis := &ProfileAnalysis{
 		Strengths:   make([]string, 0),
@@ -232,9 +299,9 @@ func parseGeminiResponse(response string) *ProfileAnalysis {
 	return analysis
 }
 
-func printAnalysis(user *GitHubUser, analysis *ProfileAnalysis) {
+func printAnalysis(profiles []*DeveloperProfile, analysis *ProfileAnalysis) {
 	fmt.Printf("\n%s\n", strings.Repeat("=", 60))
-	fmt.Printf("Profile Analysis for %s\n", user.Name)
+	fmt.Printf("Profile Analysis for %s\n", profiles[0].Name)
 	fmt.Printf("%s\n\n", strings.Repeat("=", 60))
 
 	fmt.Printf("🎭 Developer Personality Type:\n%s\n\n", 
@@ -265,6 +332,16 @@ func printAnalysis(user *GitHubUser, analysis *ProfileAnalysis) {
 	fmt.Println()
 
 	fmt.Printf("📊 Activity Level: %s\n", analysis.ActivityLevel)
+
+	fmt.Printf("\n🔒 Security Findings: %s\n", summarizeFindings(analysis.SecurityFindings))
+	for _, finding := range analysis.SecurityFindings {
+		verified := ""
+		if finding.Verified {
+			verified = " (verified)"
+		}
+		fmt.Printf("  • %s:%s:%d [%s]%s\n", finding.Repo, finding.Path, finding.Line, finding.Detector, verified)
+	}
+
 	fmt.Printf("%s\n", strings.Repeat("=", 60))
 }
 
@@ -295,35 +372,35 @@ func getUserInput() string {
 
 func main() {
     // Load environment variables from .env file
-    loadEnv()
-
-    // Get environment variables
-    githubToken := os.Getenv("GITHUB_TOKEN")
-    geminiKey := os.Getenv("GEMINI_API_KEY")
+    if err := loadEnv(); err != nil {
+        log.Fatal(err)
+    }
 
-    if githubToken == "" || geminiKey == "" {
-        log.Fatal("Please set GITHUB_TOKEN and GEMINI_API_KEY in your .env file")
+    if len(os.Args) < 2 {
+        printModeUsage()
+        os.Exit(1)
     }
 
-    // Get GitHub username using the improved input function
-    username := getUserInput()
+    modeName := os.Args[1]
+    if modeName == "-h" || modeName == "--help" || modeName == "help" {
+        printModeUsage()
+        return
+    }
 
-    // Initialize Gemini client
-    ctx := context.Background()
-    client, err := genai.NewClient(ctx, option.WithAPIKey(geminiKey))
-    if err != nil {
-        log.Fatal(err)
+    mode, ok := modes[modeName]
+    if !ok {
+        fmt.Fprintf(os.Stderr, "unknown mode %q\n\n", modeName)
+        printModeUsage()
+        os.Exit(1)
     }
-    defer client.Close()
 
-    fmt.Printf("\nAnalyzing GitHub profile for %s...\n", username)
+    args := os.Args[2:]
+    if len(args) == 0 && modeName != "serve" {
+        // Fall back to the interactive prompt for a pleasant first-run experience.
+        args = []string{getUserInput()}
+    }
 
-    // Fetch GitHub profile data
-    userData, repos := fetchGitHubData(username, githubToken)
-    
-    // Generate profile analysis
-    analysis := generateProfileAnalysis(ctx, client, userData, repos)
-    
-    // Print results
-    printAnalysis(userData, analysis)
+    if err := mode.fn(context.Background(), args); err != nil {
+        log.Fatalf("%s: %v", modeName, err)
+    }
 }
\ No newline at end of file
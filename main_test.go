@@ -0,0 +1,69 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseProfileAnalysisStructuredJSON(t *testing.T) {
+	response := `{
+		"PersonalityType": "Pragmatic Builder",
+		"Strengths": ["ships fast", "tests thoroughly"],
+		"Areas": ["docs"],
+		"Suggestions": ["write more READMEs"],
+		"TechStack": ["Go", "Python"],
+		"ActivityLevel": "High"
+	}`
+
+	analysis := parseProfileAnalysis(response)
+
+	if analysis.PersonalityType != "Pragmatic Builder" {
+		t.Errorf("PersonalityType = %q, want %q", analysis.PersonalityType, "Pragmatic Builder")
+	}
+	if !reflect.DeepEqual(analysis.Strengths, []string{"ships fast", "tests thoroughly"}) {
+		t.Errorf("Strengths = %v, want %v", analysis.Strengths, []string{"ships fast", "tests thoroughly"})
+	}
+	if analysis.ActivityLevel != "High" {
+		t.Errorf("ActivityLevel = %q, want %q", analysis.ActivityLevel, "High")
+	}
+}
+
+func TestParseProfileAnalysisFallsBackToRegexParser(t *testing.T) {
+	response := "Personality Type: The Night Owl\n" +
+		"Technical Strengths:\n" +
+		"- Go concurrency\n" +
+		"- API design\n" +
+		"Areas for Enhancement:\n" +
+		"- Test coverage\n" +
+		"Recommendations:\n" +
+		"- Add CI\n" +
+		"Technology Stack:\n" +
+		"- Go\n" +
+		"Activity Level: Moderate\n"
+
+	analysis := parseProfileAnalysis(response)
+
+	if analysis.PersonalityType != " The Night Owl" {
+		t.Errorf("PersonalityType = %q, want %q", analysis.PersonalityType, " The Night Owl")
+	}
+	if !reflect.DeepEqual(analysis.Strengths, []string{"Go concurrency", "API design"}) {
+		t.Errorf("Strengths = %v, want %v", analysis.Strengths, []string{"Go concurrency", "API design"})
+	}
+	if !reflect.DeepEqual(analysis.TechStack, []string{"Go"}) {
+		t.Errorf("TechStack = %v, want %v", analysis.TechStack, []string{"Go"})
+	}
+	if analysis.ActivityLevel != " Moderate" {
+		t.Errorf("ActivityLevel = %q, want %q", analysis.ActivityLevel, " Moderate")
+	}
+}
+
+func TestParseGeminiResponseEmptyOnUnrecognizedText(t *testing.T) {
+	analysis := parseGeminiResponse("not in the expected format at all")
+
+	if analysis.PersonalityType != "" {
+		t.Errorf("PersonalityType = %q, want empty", analysis.PersonalityType)
+	}
+	if len(analysis.Strengths) != 0 || len(analysis.Areas) != 0 || len(analysis.Suggestions) != 0 || len(analysis.TechStack) != 0 {
+		t.Errorf("expected all slices empty, got %+v", analysis)
+	}
+}
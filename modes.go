@@ -0,0 +1,319 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// modeHandler is a single CLI mode: a runnable function plus the one-line
+// description shown in --help. Modeled on gopherstats' `-mode` dispatch table.
+type modeHandler struct {
+	fn   func(ctx context.Context, args []string) error
+	desc string
+}
+
+// modes enumerates every subcommand this CLI supports. New analytics should
+// register here rather than growing main() with another flag.
+var modes = map[string]modeHandler{
+	"fetch": {
+		fn:   runFetchMode,
+		desc: "incrementally sync a GitHub user's activity into the local corpus",
+	},
+	"issue-stats": {
+		fn:   runIssueStatsMode,
+		desc: "summarize issues opened/closed from the local corpus",
+	},
+	"cl-stats": {
+		fn:   runCLStatsMode,
+		desc: "summarize PR review latency and merge rate from the local corpus",
+	},
+	"collab-graph": {
+		fn:   runCollabGraphMode,
+		desc: "map co-contributors across the repos in the local corpus",
+	},
+	"personality": {
+		fn:   runPersonalityMode,
+		desc: "generate a Gemini-backed personality analysis from the local corpus",
+	},
+	"serve": {
+		fn:   runServeMode,
+		desc: "run an HTTP server exposing /analyze, /badge and /healthz",
+	},
+}
+
+func printModeUsage() {
+	fmt.Fprintln(os.Stderr, "Usage: developer-personalities <mode> [username] [flags]")
+	fmt.Fprintln(os.Stderr, "\nModes:")
+
+	names := make([]string, 0, len(modes))
+	for name := range modes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-14s %s\n", name, modes[name].desc)
+	}
+}
+
+func requireUsername(args []string) (string, error) {
+	if len(args) == 0 || args[0] == "" {
+		return "", fmt.Errorf("a GitHub username is required")
+	}
+	if err := validateUsername(args[0]); err != nil {
+		return "", err
+	}
+	return args[0], nil
+}
+
+func runFetchMode(ctx context.Context, args []string) error {
+	username, err := requireUsername(args)
+	if err != nil {
+		return err
+	}
+
+	githubToken := os.Getenv("GITHUB_TOKEN")
+	if githubToken == "" {
+		return fmt.Errorf("GITHUB_TOKEN must be set to fetch")
+	}
+
+	corpus, err := loadCorpus(username)
+	if err != nil {
+		return err
+	}
+
+	if err := fetchIncremental(corpus, githubToken); err != nil {
+		return err
+	}
+
+	if err := saveCorpus(corpus); err != nil {
+		return err
+	}
+
+	fmt.Printf("fetched %s: %d commits, %d issues, %d pull requests, %d comments\n",
+		username, len(corpus.Commits), len(corpus.Issues), len(corpus.PullRequests), len(corpus.Comments))
+	return nil
+}
+
+func runIssueStatsMode(ctx context.Context, args []string) error {
+	username, err := requireUsername(args)
+	if err != nil {
+		return err
+	}
+
+	corpus, err := loadCorpus(username)
+	if err != nil {
+		return err
+	}
+
+	opened, closed := 0, 0
+	for _, issue := range corpus.Issues {
+		if issue.State == "closed" {
+			closed++
+		} else {
+			opened++
+		}
+	}
+
+	fmt.Printf("Issue stats for %s:\n", username)
+	fmt.Printf("  open:   %d\n", opened)
+	fmt.Printf("  closed: %d\n", closed)
+	return nil
+}
+
+func runCLStatsMode(ctx context.Context, args []string) error {
+	username, err := requireUsername(args)
+	if err != nil {
+		return err
+	}
+
+	corpus, err := loadCorpus(username)
+	if err != nil {
+		return err
+	}
+
+	var authored, merged int
+	var totalReviewLatency float64
+	var reviewed int
+
+	for _, pr := range corpus.PullRequests {
+		if pr.Author {
+			authored++
+			if !pr.MergedAt.IsZero() {
+				merged++
+			}
+		}
+		if pr.Reviewer && !pr.FirstReview.IsZero() {
+			reviewed++
+			totalReviewLatency += pr.FirstReview.Sub(pr.OpenedAt).Hours()
+		}
+	}
+
+	fmt.Printf("CL stats for %s:\n", username)
+	fmt.Printf("  authored:    %d\n", authored)
+	if authored > 0 {
+		fmt.Printf("  merge rate:  %.1f%%\n", 100*float64(merged)/float64(authored))
+	}
+	if reviewed > 0 {
+		fmt.Printf("  avg first review latency: %.1fh over %d reviews\n", totalReviewLatency/float64(reviewed), reviewed)
+	}
+	return nil
+}
+
+func runPersonalityMode(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("personality", flag.ContinueOnError)
+	githubUser := fs.String("github", "", "GitHub username to analyze")
+	gitlabUser := fs.String("gitlab", "", "GitLab username to analyze (gitlab.com unless --gitlab-host is set)")
+	gitlabHost := fs.String("gitlab-host", "", "self-hosted GitLab host (e.g. gitlab.example.com)")
+	giteaTarget := fs.String("gitea", "", "self-hosted Gitea target, as \"host/user\"")
+	bitbucketUser := fs.String("bitbucket", "", "Bitbucket username to analyze")
+	skipRepos := fs.Bool("skip-repos", false, "don't fetch repository lists")
+	skipIssues := fs.Bool("skip-issues", false, "don't fetch issue counts")
+	includePRs := fs.Bool("include-prs", false, "include pull request counts")
+	includeDiscussions := fs.Bool("include-discussions", false, "include discussion counts, where supported")
+	skipSecretScan := fs.Bool("skip-secret-scan", false, "don't scan GitHub repos for leaked credentials")
+	verify := fs.Bool("verify", false, "perform a live credential check on each secret-scan match")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *githubUser == "" && *gitlabUser == "" && *giteaTarget == "" && *bitbucketUser == "" {
+		// Back-compat: `personality <username>` still means GitHub.
+		if len(fs.Args()) == 0 {
+			return fmt.Errorf("specify a GitHub username, or one of --github/--gitlab/--gitea/--bitbucket")
+		}
+		*githubUser = fs.Args()[0]
+	}
+
+	githubToken := os.Getenv("GITHUB_TOKEN")
+	geminiKey := os.Getenv("GEMINI_API_KEY")
+	if geminiKey == "" {
+		return fmt.Errorf("GEMINI_API_KEY must be set")
+	}
+
+	opts := FetchOptions{
+		SkipRepos:          *skipRepos,
+		SkipIssues:         *skipIssues,
+		IncludePRs:         *includePRs,
+		IncludeDiscussions: *includeDiscussions,
+	}
+
+	var profiles []*DeveloperProfile
+	var securityFindings []Finding
+
+	if *githubUser != "" {
+		if err := validateUsername(*githubUser); err != nil {
+			return err
+		}
+		if githubToken == "" {
+			return fmt.Errorf("GITHUB_TOKEN must be set to analyze a GitHub profile")
+		}
+		corpus, err := loadCorpus(*githubUser)
+		if err != nil {
+			return err
+		}
+		if corpus.LastFetchedAt.IsZero() && !*skipIssues {
+			fmt.Println("No local corpus yet, fetching...")
+			if err := fetchIncremental(corpus, githubToken); err != nil {
+				return err
+			}
+			if err := saveCorpus(corpus); err != nil {
+				return err
+			}
+		}
+
+		profile, err := (&GitHubSource{Token: githubToken}).FetchProfile(ctx, *githubUser, opts)
+		if err != nil {
+			return err
+		}
+		profiles = append(profiles, profile)
+
+		if !*skipSecretScan {
+			findings, err := scanProfileForSecrets(profile, githubToken, secretScanTopRepos, *verify)
+			if err != nil {
+				return err
+			}
+			securityFindings = append(securityFindings, findings...)
+		}
+	}
+
+	if *gitlabUser != "" {
+		profile, err := (&GitLabSource{Host: *gitlabHost}).FetchProfile(ctx, *gitlabUser, opts)
+		if err != nil {
+			return err
+		}
+		profiles = append(profiles, profile)
+	}
+
+	if *giteaTarget != "" {
+		profile, err := (&GiteaSource{}).FetchProfile(ctx, *giteaTarget, opts)
+		if err != nil {
+			return err
+		}
+		profiles = append(profiles, profile)
+	}
+
+	if *bitbucketUser != "" {
+		profile, err := (&BitbucketSource{}).FetchProfile(ctx, *bitbucketUser, opts)
+		if err != nil {
+			return err
+		}
+		profiles = append(profiles, profile)
+	}
+
+	client, err := newGeminiClient(ctx, geminiKey)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	analysis, err := generateProfileAnalysis(ctx, client, profiles, securityFindings)
+	if err != nil {
+		return err
+	}
+	printAnalysis(profiles, analysis)
+	return nil
+}
+
+// secretScanTopRepos bounds how many of a user's repos get swept for leaked
+// credentials, to keep `personality` runs fast.
+const secretScanTopRepos = 5
+
+func runCollabGraphMode(ctx context.Context, args []string) error {
+	username, err := requireUsername(args)
+	if err != nil {
+		return err
+	}
+
+	corpus, err := loadCorpus(username)
+	if err != nil {
+		return err
+	}
+
+	sharedRepos := make(map[string][]string) // collaborator -> repos shared with username
+	for _, c := range corpus.Collaborators {
+		sharedRepos[c.Username] = append(sharedRepos[c.Username], c.Repo)
+	}
+
+	fmt.Printf("Co-contributors of %s (by shared repos):\n", username)
+	names := make([]string, 0, len(sharedRepos))
+	for name := range sharedRepos {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if len(sharedRepos[names[i]]) != len(sharedRepos[names[j]]) {
+			return len(sharedRepos[names[i]]) > len(sharedRepos[names[j]])
+		}
+		return names[i] < names[j]
+	})
+	for _, name := range names {
+		repos := sharedRepos[name]
+		sort.Strings(repos)
+		fmt.Printf("  %-30s %d repos (%s)\n", name, len(repos), strings.Join(repos, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// FetchOptions controls how much a ProfileSource pulls for a single target,
+// mirroring trufflehog's HuggingFace source flags (--skip-repos etc.).
+type FetchOptions struct {
+	SkipRepos          bool
+	SkipIssues         bool
+	IncludePRs         bool
+	IncludeDiscussions bool
+}
+
+// DeveloperProfile is the common shape every ProfileSource populates, so
+// Gemini can reason about a developer across forges instead of being locked
+// to GitHub's GitHubUser/Repository pair.
+type DeveloperProfile struct {
+	Source      string // "github", "gitlab", "gitea", "bitbucket"
+	Host        string // self-hosted instance, e.g. "gitea.example.com"; empty for SaaS forges
+	Username    string
+	Name        string
+	Bio         string
+	Company     string
+	Location    string
+	Followers   int
+	Following   int
+	PublicRepos int
+	CreatedAt   time.Time
+
+	Repos        []Repository
+	OpenIssues   int
+	ClosedIssues int
+	PullRequests int
+	Discussions  int
+
+	// LastActivityAt is the newest repo/issue/PR update seen, used to judge
+	// whether a forge account is active or dormant.
+	LastActivityAt time.Time
+}
+
+// ProfileSource fetches a DeveloperProfile from a single forge. GitHub,
+// GitLab, Gitea and Bitbucket each implement this independently since their
+// REST shapes don't line up, but callers only ever see DeveloperProfile.
+type ProfileSource interface {
+	Name() string
+	FetchProfile(ctx context.Context, target string, opts FetchOptions) (*DeveloperProfile, error)
+}
+
+// sourceDormancyNote gives Gemini a one-line cue per source, e.g. "active on
+// gitea.example.com, dormant on github.com", by comparing LastActivityAt
+// against a simple recency threshold.
+func sourceDormancyNote(profile *DeveloperProfile) string {
+	host := profile.Host
+	if host == "" {
+		host = profile.Source
+	}
+
+	if profile.LastActivityAt.IsZero() {
+		return host + ": no recent activity seen"
+	}
+	if time.Since(profile.LastActivityAt) > 90*24*time.Hour {
+		return host + ": dormant (no activity in 90+ days)"
+	}
+	return host + ": active"
+}
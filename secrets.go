@@ -0,0 +1,252 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Finding is a single potential secret turned up by the scanner, similar in
+// shape to trufflehog's results: which repo/path/line it lives at and which
+// detector fired.
+type Finding struct {
+	Repo     string `json:"repo"`
+	Path     string `json:"path"`
+	Line     int    `json:"line"`
+	Detector string `json:"detector"`
+	Verified bool   `json:"verified"`
+}
+
+// secretDetector pairs a regex with a name; Verify is optional and, when
+// set, performs a live credential check (gated behind --verify) to
+// distinguish a real leaked secret from a regex false-positive.
+type secretDetector struct {
+	Name    string
+	Pattern *regexp.Regexp
+	Verify  func(match string) bool
+}
+
+var secretDetectors = []secretDetector{
+	{Name: "aws-access-key-id", Pattern: regexp.MustCompile(`AKIA[0-9A-Z]{16}`)},
+	{Name: "google-api-key", Pattern: regexp.MustCompile(`AIza[0-9A-Za-z\-_]{35}`)},
+	{Name: "github-pat", Pattern: regexp.MustCompile(`gh[pousr]_[0-9A-Za-z]{36}`), Verify: verifyGitHubToken},
+	{Name: "slack-token", Pattern: regexp.MustCompile(`xox[baprs]-[0-9A-Za-z-]{10,72}`)},
+	{Name: "private-key-pem", Pattern: regexp.MustCompile(`-----BEGIN (RSA |EC |DSA |OPENSSH )?PRIVATE KEY-----`)},
+}
+
+// highEntropyThreshold and minSecretLength bound the generic high-entropy
+// detector so it doesn't flag every hex hash or UUID in a repo.
+const (
+	highEntropyThreshold = 4.3
+	minSecretLength       = 20
+)
+
+// verifyGitHubToken performs a live check of a candidate GitHub PAT against
+// the users endpoint. Only runs when the caller opts into --verify, since it
+// makes an outbound request per candidate.
+func verifyGitHubToken(token string) bool {
+	req, err := http.NewRequest("GET", "https://api.github.com/user", nil)
+	if err != nil {
+		return false
+	}
+	req.Header.Add("Authorization", "Bearer "+token)
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode == http.StatusOK
+}
+
+// shannonEntropy estimates the bits-per-character entropy of s, used to flag
+// high-entropy strings (likely tokens/keys) that don't match a known vendor
+// pattern.
+func shannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+
+	counts := make(map[rune]int)
+	for _, r := range s {
+		counts[r]++
+	}
+
+	var entropy float64
+	length := float64(len(s))
+	for _, count := range counts {
+		p := float64(count) / length
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+var highEntropyCandidate = regexp.MustCompile(`[A-Za-z0-9+/_=\-]{20,}`)
+
+// scanLineForSecrets runs every known detector plus the generic high-entropy
+// check against a single line of text.
+func scanLineForSecrets(line string) []string {
+	var detectors []string
+
+	for _, d := range secretDetectors {
+		if d.Pattern.MatchString(line) {
+			detectors = append(detectors, d.Name)
+		}
+	}
+
+	for _, candidate := range highEntropyCandidate.FindAllString(line, -1) {
+		if len(candidate) >= minSecretLength && shannonEntropy(candidate) >= highEntropyThreshold {
+			detectors = append(detectors, "high-entropy-string")
+			break
+		}
+	}
+
+	return detectors
+}
+
+// scanRepoForSecrets sweeps the root-level text files of a single GitHub
+// repo via the contents API (no clone required) and returns any findings.
+func scanRepoForSecrets(owner, repo, token string, verify bool) ([]Finding, error) {
+	client := getGitHubClient(token)
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("https://api.github.com/repos/%s/%s/contents/", owner, repo), nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating contents request: %w", err)
+	}
+	req.Header.Add("Authorization", "Bearer "+token)
+	req.Header.Add("Accept", "application/vnd.github.v3+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("listing contents of %s/%s: %w", owner, repo, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// Empty/disabled repos shouldn't fail the whole scan.
+		return nil, nil
+	}
+
+	var entries []struct {
+		Name        string `json:"name"`
+		Path        string `json:"path"`
+		Type        string `json:"type"`
+		Size        int    `json:"size"`
+		DownloadURL string `json:"download_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("decoding contents of %s/%s: %w", owner, repo, err)
+	}
+
+	var findings []Finding
+	for _, entry := range entries {
+		if entry.Type != "file" || entry.Size > 1<<20 || !looksLikeTextFile(entry.Name) || entry.DownloadURL == "" {
+			continue
+		}
+
+		body, err := fetchRaw(client, entry.DownloadURL, token)
+		if err != nil {
+			continue // best-effort: a single unreadable file shouldn't abort the scan
+		}
+
+		for lineNum, line := range strings.Split(body, "\n") {
+			for _, detector := range scanLineForSecrets(line) {
+				finding := Finding{Repo: repo, Path: entry.Path, Line: lineNum + 1, Detector: detector}
+				if verify {
+					finding.Verified = verifyFinding(detector, line)
+				}
+				findings = append(findings, finding)
+			}
+		}
+	}
+
+	return findings, nil
+}
+
+func verifyFinding(detectorName, line string) bool {
+	for _, d := range secretDetectors {
+		if d.Name != detectorName || d.Verify == nil {
+			continue
+		}
+		match := d.Pattern.FindString(line)
+		if match == "" {
+			return false
+		}
+		return d.Verify(match)
+	}
+	return false
+}
+
+func looksLikeTextFile(name string) bool {
+	for _, ext := range []string{".go", ".py", ".js", ".ts", ".json", ".yml", ".yaml", ".env", ".sh", ".txt", ".md", ".cfg", ".ini", ".toml"} {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+func fetchRaw(client *http.Client, url, token string) (string, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Add("Authorization", "Bearer "+token)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetching %s: %s", url, resp.Status)
+	}
+
+	var buf strings.Builder
+	if _, err := io.Copy(&buf, resp.Body); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// scanProfileForSecrets scans the top N repos of a DeveloperProfile and
+// returns every finding across them.
+func scanProfileForSecrets(profile *DeveloperProfile, token string, topN int, verify bool) ([]Finding, error) {
+	var all []Finding
+
+	for i, repo := range profile.Repos {
+		if i >= topN {
+			break
+		}
+		findings, err := scanRepoForSecrets(profile.Username, repo.Name, token, verify)
+		if err != nil {
+			return nil, fmt.Errorf("scanning %s: %w", repo.Name, err)
+		}
+		all = append(all, findings...)
+	}
+
+	return all, nil
+}
+
+// summarizeFindings produces a redacted, Gemini-safe one-liner such as
+// "3 potential secrets in 2 repos" -- never the actual match text.
+func summarizeFindings(findings []Finding) string {
+	if len(findings) == 0 {
+		return "no potential secrets found"
+	}
+
+	repos := make(map[string]bool)
+	for _, f := range findings {
+		repos[f.Repo] = true
+	}
+
+	return fmt.Sprintf("%d potential secrets in %d repos", len(findings), len(repos))
+}
@@ -0,0 +1,291 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/generative-ai-go/genai"
+	"google.golang.org/api/iterator"
+)
+
+// serveCacheEntry holds a previously computed analysis so repeat requests
+// for the same username don't re-bill Gemini inside the TTL window.
+type serveCacheEntry struct {
+	Profile   *DeveloperProfile
+	Analysis  *ProfileAnalysis
+	ExpiresAt time.Time
+}
+
+// analysisServer holds everything the HTTP handlers need: the GitHub/Gemini
+// credentials, and a small per-username result cache.
+type analysisServer struct {
+	githubToken string
+	geminiKey   string
+	ttl         time.Duration
+
+	mu    sync.Mutex
+	cache map[string]serveCacheEntry
+}
+
+func (s *analysisServer) getCached(username string) (serveCacheEntry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.cache[username]
+	if !ok || time.Now().After(entry.ExpiresAt) {
+		return serveCacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (s *analysisServer) setCached(username string, entry serveCacheEntry) {
+	entry.ExpiresAt = time.Now().Add(s.ttl)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache[username] = entry
+}
+
+// fetchProfileAndFindings fetches a GitHub DeveloperProfile plus a
+// secret-scan pass, the same inputs the CLI's personality mode builds.
+func (s *analysisServer) fetchProfileAndFindings(ctx context.Context, username string) (*DeveloperProfile, []Finding, error) {
+	profile, err := (&GitHubSource{Token: s.githubToken}).FetchProfile(ctx, username, FetchOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	findings, err := scanProfileForSecrets(profile, s.githubToken, secretScanTopRepos, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return profile, findings, nil
+}
+
+func (s *analysisServer) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintln(w, `{"status":"ok"}`)
+}
+
+// handleAnalyzeStream implements POST /analyze, streaming Gemini's response
+// token-by-token via Server-Sent Events as it's generated.
+func (s *analysisServer) handleAnalyzeStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body struct {
+		Username string `json:"username"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Username == "" {
+		http.Error(w, "expected JSON body with a \"username\" field", http.StatusBadRequest)
+		return
+	}
+	if err := validateUsername(body.Username); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	ctx := r.Context()
+	profile, findings, err := s.fetchProfileAndFindings(ctx, body.Username)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	client, err := newGeminiClient(ctx, s.geminiKey)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer client.Close()
+
+	model := client.GenerativeModel("gemini-pro")
+	prompt := buildAnalysisPrompt([]*DeveloperProfile{profile}, findings)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	var full strings.Builder
+	iter := model.GenerateContentStream(ctx, genai.Text(prompt))
+	for {
+		resp, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			fmt.Fprintf(w, "event: error\ndata: %s\n\n", err.Error())
+			flusher.Flush()
+			return
+		}
+
+		for _, candidate := range resp.Candidates {
+			for _, part := range candidate.Content.Parts {
+				if text, ok := part.(genai.Text); ok {
+					full.WriteString(string(text))
+					fmt.Fprintf(w, "data: %s\n\n", strings.ReplaceAll(string(text), "\n", "\ndata: "))
+					flusher.Flush()
+				}
+			}
+		}
+	}
+
+	analysis := parseProfileAnalysis(full.String())
+	analysis.SecurityFindings = findings
+	s.setCached(body.Username, serveCacheEntry{Profile: profile, Analysis: analysis})
+
+	fmt.Fprint(w, "event: done\ndata: {}\n\n")
+	flusher.Flush()
+}
+
+// handleAnalyzeJSON implements GET /analyze/{username}.json, returning the
+// parsed ProfileAnalysis (computing and caching it if not already cached).
+func (s *analysisServer) handleAnalyzeJSON(w http.ResponseWriter, r *http.Request) {
+	username := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/analyze/"), ".json")
+	if username == "" {
+		http.Error(w, "missing username", http.StatusBadRequest)
+		return
+	}
+	if err := validateUsername(username); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	entry, err := s.analyzeAndCache(r.Context(), username)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entry.Analysis)
+}
+
+// handleBadge implements GET /badge/{username}/personality.svg, a
+// shields.io-style SVG badge showing the archetype and activity level.
+func (s *analysisServer) handleBadge(w http.ResponseWriter, r *http.Request) {
+	username := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/badge/"), "/personality.svg")
+	if username == "" {
+		http.Error(w, "missing username", http.StatusBadRequest)
+		return
+	}
+	if err := validateUsername(username); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	entry, err := s.analyzeAndCache(r.Context(), username)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	fmt.Fprint(w, renderPersonalityBadge(entry.Analysis.PersonalityType, entry.Analysis.ActivityLevel))
+}
+
+// analyzeAndCache returns the cached entry for username if still fresh,
+// otherwise runs a one-shot (non-streaming) analysis and caches it.
+func (s *analysisServer) analyzeAndCache(ctx context.Context, username string) (serveCacheEntry, error) {
+	if entry, ok := s.getCached(username); ok {
+		return entry, nil
+	}
+
+	profile, findings, err := s.fetchProfileAndFindings(ctx, username)
+	if err != nil {
+		return serveCacheEntry{}, err
+	}
+
+	client, err := newGeminiClient(ctx, s.geminiKey)
+	if err != nil {
+		return serveCacheEntry{}, err
+	}
+	defer client.Close()
+
+	analysis, err := generateProfileAnalysis(ctx, client, []*DeveloperProfile{profile}, findings)
+	if err != nil {
+		return serveCacheEntry{}, err
+	}
+
+	entry := serveCacheEntry{Profile: profile, Analysis: analysis}
+	s.setCached(username, entry)
+	return entry, nil
+}
+
+// renderPersonalityBadge draws a minimal two-box SVG badge in the style of
+// shields.io: a label box and a value box showing the archetype + activity.
+func renderPersonalityBadge(personalityType, activityLevel string) string {
+	label := "dev personality"
+	value := strings.TrimSpace(personalityType)
+	if activityLevel != "" {
+		value = fmt.Sprintf("%s · %s", value, strings.TrimSpace(activityLevel))
+	}
+	if value == "" {
+		value = "unknown"
+	}
+
+	labelWidth := 10*len(label) + 20
+	valueWidth := 10*len(value) + 20
+	width := labelWidth + valueWidth
+
+	// personalityType/activityLevel come from Gemini output, itself shaped by
+	// the analyzed user's bio/repo text, so they must be XML-escaped before
+	// landing in SVG markup served to public README badges.
+	escapedLabel := html.EscapeString(label)
+	escapedValue := html.EscapeString(value)
+
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20">
+  <rect width="%d" height="20" fill="#555"/>
+  <rect x="%d" width="%d" height="20" fill="#4c1"/>
+  <text x="%d" y="14" fill="#fff" font-family="Verdana" font-size="11" text-anchor="middle">%s</text>
+  <text x="%d" y="14" fill="#fff" font-family="Verdana" font-size="11" text-anchor="middle">%s</text>
+</svg>`, width, labelWidth, labelWidth, valueWidth, labelWidth/2, escapedLabel, labelWidth+valueWidth/2, escapedValue)
+}
+
+func runServeMode(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", ":8080", "address to listen on")
+	cacheTTL := fs.Duration("cache-ttl", time.Hour, "how long to cache a username's analysis before re-billing Gemini")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	githubToken := os.Getenv("GITHUB_TOKEN")
+	geminiKey := os.Getenv("GEMINI_API_KEY")
+	if githubToken == "" || geminiKey == "" {
+		return fmt.Errorf("GITHUB_TOKEN and GEMINI_API_KEY must be set")
+	}
+
+	server := &analysisServer{
+		githubToken: githubToken,
+		geminiKey:   geminiKey,
+		ttl:         *cacheTTL,
+		cache:       make(map[string]serveCacheEntry),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", server.handleHealthz)
+	mux.HandleFunc("/analyze", server.handleAnalyzeStream)
+	mux.HandleFunc("/analyze/", server.handleAnalyzeJSON)
+	mux.HandleFunc("/badge/", server.handleBadge)
+
+	log.Printf("listening on %s", *addr)
+	return http.ListenAndServe(*addr, mux)
+}
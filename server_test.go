@@ -0,0 +1,53 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestAnalysisServer() *analysisServer {
+	return &analysisServer{cache: make(map[string]serveCacheEntry)}
+}
+
+func TestHandleAnalyzeJSONRejectsPathTraversal(t *testing.T) {
+	s := newTestAnalysisServer()
+
+	req := httptest.NewRequest(http.MethodGet, "/analyze/..%2F..%2F..%2Fetc%2Fpasswd.json", nil)
+	req.URL.Path = "/analyze/../../../etc/passwd.json"
+	w := httptest.NewRecorder()
+
+	s.handleAnalyzeJSON(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d; body=%s", w.Code, http.StatusBadRequest, w.Body.String())
+	}
+}
+
+func TestHandleBadgeRejectsPathTraversal(t *testing.T) {
+	s := newTestAnalysisServer()
+
+	req := httptest.NewRequest(http.MethodGet, "/badge/../../../etc/passwd/personality.svg", nil)
+	req.URL.Path = "/badge/../../../etc/passwd/personality.svg"
+	w := httptest.NewRecorder()
+
+	s.handleBadge(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d; body=%s", w.Code, http.StatusBadRequest, w.Body.String())
+	}
+}
+
+func TestHandleAnalyzeStreamRejectsPathTraversal(t *testing.T) {
+	s := newTestAnalysisServer()
+
+	req := httptest.NewRequest(http.MethodPost, "/analyze", strings.NewReader(`{"username":"../../../etc/passwd"}`))
+	w := httptest.NewRecorder()
+
+	s.handleAnalyzeStream(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d; body=%s", w.Code, http.StatusBadRequest, w.Body.String())
+	}
+}
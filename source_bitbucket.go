@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// bitbucketAPIBase is the Bitbucket Cloud REST API root. It's a var rather
+// than an inline literal so tests can point it at an httptest server.
+var bitbucketAPIBase = "https://api.bitbucket.org"
+
+// BitbucketSource fetches a DeveloperProfile from bitbucket.org.
+type BitbucketSource struct {
+	AppPassword string
+
+	// httpClient overrides the client used by get, for pointing tests at an
+	// httptest server; nil means construct the default client.
+	httpClient *http.Client
+}
+
+func (s *BitbucketSource) Name() string { return "bitbucket" }
+
+func (s *BitbucketSource) get(url string, out interface{}) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+	if s.AppPassword != "" {
+		req.Header.Add("Authorization", "Bearer "+s.AppPassword)
+	}
+
+	client := s.httpClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Bitbucket API error: %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (s *BitbucketSource) FetchProfile(ctx context.Context, target string, opts FetchOptions) (*DeveloperProfile, error) {
+	var account struct {
+		Username  string `json:"username"`
+		DisplayName string `json:"display_name"`
+		Location  string `json:"location"`
+		CreatedOn time.Time `json:"created_on"`
+	}
+	if err := s.get(fmt.Sprintf("%s/2.0/users/%s", bitbucketAPIBase, target), &account); err != nil {
+		return nil, fmt.Errorf("fetching Bitbucket user %s: %w", target, err)
+	}
+
+	profile := &DeveloperProfile{
+		Source:    "bitbucket",
+		Username:  account.Username,
+		Name:      account.DisplayName,
+		Location:  account.Location,
+		CreatedAt: account.CreatedOn,
+	}
+
+	if !opts.SkipRepos {
+		var page struct {
+			Values []struct {
+				Name        string    `json:"name"`
+				Description string    `json:"description"`
+				Language    string    `json:"language"`
+				IsPrivate   bool      `json:"is_private"`
+				CreatedOn   time.Time `json:"created_on"`
+				UpdatedOn   time.Time `json:"updated_on"`
+			} `json:"values"`
+		}
+		if err := s.get(fmt.Sprintf("%s/2.0/repositories/%s", bitbucketAPIBase, target), &page); err != nil {
+			return nil, fmt.Errorf("fetching Bitbucket repos for %s: %w", target, err)
+		}
+
+		profile.PublicRepos = len(page.Values)
+		for _, r := range page.Values {
+			profile.Repos = append(profile.Repos, Repository{
+				Name:        r.Name,
+				Description: r.Description,
+				Language:    r.Language,
+				CreatedAt:   r.CreatedOn,
+				UpdatedAt:   r.UpdatedOn,
+			})
+			if r.UpdatedOn.After(profile.LastActivityAt) {
+				profile.LastActivityAt = r.UpdatedOn
+			}
+		}
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// GiteaSource fetches a DeveloperProfile from a self-hosted Gitea instance.
+// Targets are passed as "host/user" (e.g. "gitea.example.com/alice") since
+// Gitea has no single canonical SaaS host the way GitHub/GitLab do.
+type GiteaSource struct {
+	Token string
+
+	// httpClient overrides the client used by get, for pointing tests at an
+	// httptest server; nil means construct the default client.
+	httpClient *http.Client
+}
+
+func (s *GiteaSource) Name() string { return "gitea" }
+
+// splitGiteaTarget parses a "host/user" target into its parts.
+func splitGiteaTarget(target string) (host, user string, err error) {
+	parts := strings.SplitN(target, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("gitea target must be \"host/user\", got %q", target)
+	}
+	return parts[0], parts[1], nil
+}
+
+func (s *GiteaSource) get(url string, out interface{}) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+	if s.Token != "" {
+		req.Header.Add("Authorization", "token "+s.Token)
+	}
+
+	client := s.httpClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Gitea API error: %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (s *GiteaSource) FetchProfile(ctx context.Context, target string, opts FetchOptions) (*DeveloperProfile, error) {
+	host, user, err := splitGiteaTarget(target)
+	if err != nil {
+		return nil, err
+	}
+	base := "https://" + host + "/api/v1"
+
+	var account struct {
+		Login     string    `json:"login"`
+		FullName  string    `json:"full_name"`
+		Location  string    `json:"location"`
+		CreatedAt time.Time `json:"created"`
+	}
+	if err := s.get(fmt.Sprintf("%s/users/%s", base, user), &account); err != nil {
+		return nil, fmt.Errorf("fetching Gitea user %s/%s: %w", host, user, err)
+	}
+
+	profile := &DeveloperProfile{
+		Source:    "gitea",
+		Host:      host,
+		Username:  account.Login,
+		Name:      account.FullName,
+		Location:  account.Location,
+		CreatedAt: account.CreatedAt,
+	}
+
+	if !opts.SkipRepos {
+		var repos []struct {
+			Name        string    `json:"name"`
+			Description string    `json:"description"`
+			Stars       int       `json:"stars_count"`
+			Fork        bool      `json:"fork"`
+			Language    string    `json:"language"`
+			CreatedAt   time.Time `json:"created_at"`
+			UpdatedAt   time.Time `json:"updated_at"`
+		}
+		if err := s.get(fmt.Sprintf("%s/users/%s/repos", base, user), &repos); err != nil {
+			return nil, fmt.Errorf("fetching Gitea repos for %s/%s: %w", host, user, err)
+		}
+
+		profile.PublicRepos = len(repos)
+		for _, r := range repos {
+			profile.Repos = append(profile.Repos, Repository{
+				Name:        r.Name,
+				Description: r.Description,
+				Language:    r.Language,
+				Stars:       r.Stars,
+				Fork:        r.Fork,
+				CreatedAt:   r.CreatedAt,
+				UpdatedAt:   r.UpdatedAt,
+			})
+			if r.UpdatedAt.After(profile.LastActivityAt) {
+				profile.LastActivityAt = r.UpdatedAt
+			}
+		}
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// GitHubSource adapts the existing GitHub fetch path to the ProfileSource
+// interface.
+type GitHubSource struct {
+	Token string
+}
+
+func (s *GitHubSource) Name() string { return "github" }
+
+func (s *GitHubSource) FetchProfile(ctx context.Context, target string, opts FetchOptions) (*DeveloperProfile, error) {
+	user, repos, err := fetchGitHubData(target, s.Token)
+	if err != nil {
+		return nil, fmt.Errorf("fetching GitHub profile for %s: %w", target, err)
+	}
+
+	profile := &DeveloperProfile{
+		Source:      "github",
+		Username:    user.Login,
+		Name:        user.Name,
+		Bio:         user.Bio,
+		Company:     user.Company,
+		Location:    user.Location,
+		Followers:   user.Followers,
+		Following:   user.Following,
+		PublicRepos: user.PublicRepos,
+		CreatedAt:   user.CreatedAt,
+	}
+
+	if !opts.SkipRepos {
+		profile.Repos = repos
+		for _, repo := range repos {
+			if repo.UpdatedAt.After(profile.LastActivityAt) {
+				profile.LastActivityAt = repo.UpdatedAt
+			}
+		}
+	}
+
+	if !opts.SkipIssues {
+		corpus, err := loadCorpus(target)
+		if err == nil {
+			for _, issue := range corpus.Issues {
+				if issue.State == "closed" {
+					profile.ClosedIssues++
+				} else {
+					profile.OpenIssues++
+				}
+			}
+			if opts.IncludePRs {
+				profile.PullRequests = len(corpus.PullRequests)
+			}
+		}
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// GitLabSource fetches a DeveloperProfile from gitlab.com (or a self-hosted
+// instance, via Host).
+type GitLabSource struct {
+	Host  string // defaults to gitlab.com
+	Token string
+
+	// httpClient overrides the client used by get, for pointing tests at an
+	// httptest server; nil means construct the default client.
+	httpClient *http.Client
+}
+
+func (s *GitLabSource) Name() string { return "gitlab" }
+
+func (s *GitLabSource) baseURL() string {
+	if s.Host == "" {
+		return "https://gitlab.com/api/v4"
+	}
+	return "https://" + s.Host + "/api/v4"
+}
+
+func (s *GitLabSource) get(url string, out interface{}) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+	if s.Token != "" {
+		req.Header.Add("PRIVATE-TOKEN", s.Token)
+	}
+
+	client := s.httpClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GitLab API error: %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (s *GitLabSource) FetchProfile(ctx context.Context, target string, opts FetchOptions) (*DeveloperProfile, error) {
+	var users []struct {
+		ID        int       `json:"id"`
+		Username  string    `json:"username"`
+		Name      string    `json:"name"`
+		Bio       string    `json:"bio"`
+		Location  string    `json:"location"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+	if err := s.get(fmt.Sprintf("%s/users?username=%s", s.baseURL(), target), &users); err != nil {
+		return nil, fmt.Errorf("fetching GitLab user %s: %w", target, err)
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("GitLab user %s not found", target)
+	}
+	user := users[0]
+
+	profile := &DeveloperProfile{
+		Source:    "gitlab",
+		Host:      s.Host,
+		Username:  user.Username,
+		Name:      user.Name,
+		Bio:       user.Bio,
+		Location:  user.Location,
+		CreatedAt: user.CreatedAt,
+	}
+
+	if !opts.SkipRepos {
+		var projects []struct {
+			Name           string    `json:"name"`
+			Description    string    `json:"description"`
+			StarCount      int       `json:"star_count"`
+			CreatedAt      time.Time `json:"created_at"`
+			LastActivityAt time.Time `json:"last_activity_at"`
+		}
+		if err := s.get(fmt.Sprintf("%s/users/%d/projects", s.baseURL(), user.ID), &projects); err != nil {
+			return nil, fmt.Errorf("fetching GitLab projects for %s: %w", target, err)
+		}
+
+		profile.PublicRepos = len(projects)
+		for _, p := range projects {
+			profile.Repos = append(profile.Repos, Repository{
+				Name:        p.Name,
+				Description: p.Description,
+				Stars:       p.StarCount,
+				CreatedAt:   p.CreatedAt,
+				UpdatedAt:   p.LastActivityAt,
+			})
+			if p.LastActivityAt.After(profile.LastActivityAt) {
+				profile.LastActivityAt = p.LastActivityAt
+			}
+		}
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGitHubSourceFetchProfileDecodesFixture(t *testing.T) {
+	withTempCacheDir(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users/octocat", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"login":"octocat","name":"The Octocat","bio":"mascot","public_repos":1}`))
+	})
+	mux.HandleFunc("/users/octocat/repos", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"name":"Hello-World","description":"demo repo","stargazers_count":5,"updated_at":"2024-01-01T00:00:00Z"}]`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	prevBase := githubAPIBase
+	githubAPIBase = server.URL
+	defer func() { githubAPIBase = prevBase }()
+
+	source := &GitHubSource{Token: "test-token"}
+	profile, err := source.FetchProfile(context.Background(), "octocat", FetchOptions{SkipIssues: true})
+	if err != nil {
+		t.Fatalf("FetchProfile: %v", err)
+	}
+
+	if profile.Username != "octocat" || profile.Name != "The Octocat" {
+		t.Errorf("profile = %+v, want username=octocat name=\"The Octocat\"", profile)
+	}
+	if len(profile.Repos) != 1 || profile.Repos[0].Name != "Hello-World" {
+		t.Errorf("Repos = %+v, want one repo named Hello-World", profile.Repos)
+	}
+}
+
+func TestGitLabSourceFetchProfileDecodesFixture(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/users", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id":7,"username":"ada","name":"Ada Lovelace","bio":"mathematician"}]`))
+	})
+	mux.HandleFunc("/api/v4/users/7/projects", func(w http.ResponseWriter, r *http.Request) {
+		// A forked project's forked_from_project is an object, not a bool;
+		// this is the fixture that previously broke decoding entirely.
+		w.Write([]byte(`[{"name":"analytical-engine","star_count":3,"forked_from_project":{"id":1,"name":"upstream"}}]`))
+	})
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	source := &GitLabSource{
+		Host:       strings.TrimPrefix(server.URL, "https://"),
+		httpClient: server.Client(),
+	}
+	profile, err := source.FetchProfile(context.Background(), "ada", FetchOptions{})
+	if err != nil {
+		t.Fatalf("FetchProfile: %v", err)
+	}
+
+	if profile.Username != "ada" || profile.Name != "Ada Lovelace" {
+		t.Errorf("profile = %+v, want username=ada name=\"Ada Lovelace\"", profile)
+	}
+	if len(profile.Repos) != 1 || profile.Repos[0].Name != "analytical-engine" {
+		t.Errorf("Repos = %+v, want one repo named analytical-engine", profile.Repos)
+	}
+}
+
+func TestGiteaSourceFetchProfileDecodesFixture(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/users/grace", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"login":"grace","full_name":"Grace Hopper","location":"Arlington","created":"2020-01-01T00:00:00Z"}`))
+	})
+	mux.HandleFunc("/api/v1/users/grace/repos", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"name":"compiler","stars_count":2,"fork":false,"language":"COBOL","updated_at":"2024-06-01T00:00:00Z"}]`))
+	})
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "https://")
+	source := &GiteaSource{httpClient: server.Client()}
+	profile, err := source.FetchProfile(context.Background(), host+"/grace", FetchOptions{})
+	if err != nil {
+		t.Fatalf("FetchProfile: %v", err)
+	}
+
+	if profile.Username != "grace" || profile.Name != "Grace Hopper" {
+		t.Errorf("profile = %+v, want username=grace name=\"Grace Hopper\"", profile)
+	}
+	if len(profile.Repos) != 1 || profile.Repos[0].Language != "COBOL" {
+		t.Errorf("Repos = %+v, want one COBOL repo", profile.Repos)
+	}
+}
+
+func TestGiteaSourceFetchProfileRejectsMalformedTarget(t *testing.T) {
+	source := &GiteaSource{}
+	if _, err := source.FetchProfile(context.Background(), "no-slash-here", FetchOptions{}); err == nil {
+		t.Fatal("expected an error for a target without \"host/user\" shape")
+	}
+}
+
+func TestBitbucketSourceFetchProfileDecodesFixture(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/2.0/users/tpratchett", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"username":"tpratchett","display_name":"Terry Pratchett","created_on":"2019-03-01T00:00:00Z"}`))
+	})
+	mux.HandleFunc("/2.0/repositories/tpratchett", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"values":[{"name":"discworld","language":"Go","updated_on":"2024-02-01T00:00:00Z"}]}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	prevBase := bitbucketAPIBase
+	bitbucketAPIBase = server.URL
+	defer func() { bitbucketAPIBase = prevBase }()
+
+	source := &BitbucketSource{}
+	profile, err := source.FetchProfile(context.Background(), "tpratchett", FetchOptions{})
+	if err != nil {
+		t.Fatalf("FetchProfile: %v", err)
+	}
+
+	if profile.Username != "tpratchett" || profile.Name != "Terry Pratchett" {
+		t.Errorf("profile = %+v, want username=tpratchett name=\"Terry Pratchett\"", profile)
+	}
+	if len(profile.Repos) != 1 || profile.Repos[0].Name != "discworld" {
+		t.Errorf("Repos = %+v, want one repo named discworld", profile.Repos)
+	}
+}